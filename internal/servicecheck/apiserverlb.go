@@ -0,0 +1,38 @@
+package servicecheck
+
+import (
+	"log/slog"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// discoverAPIServerLBURL resolves the external API server LoadBalancer/HTTPS URL used by
+// APIServerLoadBalancer when KUBENURSE_APISERVER_LB_URL isn't set, by falling back to the
+// "server" field of the kubeconfig the process is running with (the same one used to build the
+// controller-runtime client). This is best-effort: kubenurse normally runs in-cluster with no
+// kubeconfig file on disk, in which case the check simply stays skippedStr until an operator sets
+// the env var explicitly.
+func discoverAPIServerLBURL() string {
+	// RawConfig, unlike ClientConfig, never falls back to the in-cluster config, whose host is the
+	// internal service address rather than the external LoadBalancer.
+	rawCfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{},
+	).RawConfig()
+	if err != nil {
+		slog.Debug("no kubeconfig available to discover the API server LoadBalancer URL, leaving APIServerLoadBalancer check skipped unless KUBENURSE_APISERVER_LB_URL is set", "err", err)
+		return ""
+	}
+
+	kubeContext, ok := rawCfg.Contexts[rawCfg.CurrentContext]
+	if !ok || kubeContext == nil {
+		return ""
+	}
+
+	cluster, ok := rawCfg.Clusters[kubeContext.Cluster]
+	if !ok || cluster == nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(cluster.Server, "/")
+}
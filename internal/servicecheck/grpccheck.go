@@ -0,0 +1,193 @@
+package servicecheck
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+)
+
+// grpcPort is the port kubenurse's gRPC health service listens on, the gRPC equivalent of the
+// :8443/:8080 ports used for the HTTP /alwayshappy checks.
+const grpcPort = "9000"
+
+// protocolGRPC is the value of the "protocol" label attached to metrics emitted by gRPC checks,
+// so they can be told apart from the default HTTP checks in the same histograms/counters.
+const protocolGRPC = "grpc"
+
+// podIPtoGRPCTarget builds the dial target for a neighbour's/service's gRPC health endpoint,
+// the gRPC counterpart of podIPtoURL.
+func podIPtoGRPCTarget(podIP string) string {
+	return podIP + ":" + grpcPort
+}
+
+// grpcConnPool lazily dials and caches one *grpc.ClientConn per target, so repeated checks
+// against the same neighbour/service reuse the connection (and its keepalive) instead of
+// dialing from scratch every run, mirroring the keep-alive behaviour of the HTTP transport.
+type grpcConnPool struct {
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// grpcConn returns a cached or newly-dialed connection to target.
+func (c *Checker) grpcConn(target string) (*grpc.ClientConn, error) {
+	c.grpcPool.mu.Lock()
+	defer c.grpcPool.mu.Unlock()
+
+	if c.grpcPool.conns == nil {
+		c.grpcPool.conns = make(map[string]*grpc.ClientConn)
+	}
+
+	if conn, ok := c.grpcPool.conns[target]; ok {
+		return conn, nil
+	}
+
+	creds := credentials.TransportCredentials(insecure.NewCredentials())
+	if c.UseTLS {
+		grpcTLSConfig := &tls.Config{} //nolint:gosec // MinVersion/CipherSuites set by applyTLSProfile below
+		if err := applyTLSProfile(grpcTLSConfig, c.tlsProfile); err != nil {
+			return nil, fmt.Errorf("cannot apply tls profile to grpc target %q: %w", target, err)
+		}
+
+		grpcTLSConfig.InsecureSkipVerify = os.Getenv("KUBENURSE_INSECURE") == "true" //nolint:gosec // explicit opt-in via KUBENURSE_INSECURE
+
+		creds = credentials.NewTLS(grpcTLSConfig)
+	}
+
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot dial grpc target %q: %w", target, err)
+	}
+
+	c.grpcPool.conns[target] = conn
+
+	return conn, nil
+}
+
+// closeGRPCConn closes and evicts the cached connection to target, if any. It is called once a
+// neighbour using gRPC health checks disappears (refreshNeighbourhood), so the pool doesn't grow
+// forever as pods are rescheduled onto new IPs; it is a no-op for a target that was never dialed.
+func (c *Checker) closeGRPCConn(target string) {
+	c.grpcPool.mu.Lock()
+	defer c.grpcPool.mu.Unlock()
+
+	conn, ok := c.grpcPool.conns[target]
+	if !ok {
+		return
+	}
+
+	delete(c.grpcPool.conns, target)
+
+	if err := conn.Close(); err != nil {
+		slog.Warn("error closing stale grpc connection", "target", target, "err", err)
+	}
+}
+
+// grpcLatencyHistogramVec lazily creates (once) and returns the histogram used to record gRPC
+// check latency, labelled the same way as the HTTP check metrics plus protocol="grpc".
+func (c *Checker) grpcLatencyHistogramVec(promRegistry *prometheus.Registry, buckets []float64) *prometheus.HistogramVec {
+	c.grpcHistogramOnce.Do(func() {
+		c.grpcHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Name:      "grpc_check_duration_seconds",
+			Help:      "Duration of a gRPC health check, labelled by check name, result and protocol.",
+			Buckets:   buckets,
+		}, []string{"check", "result", "protocol"})
+
+		promRegistry.MustRegister(c.grpcHistogram)
+	})
+
+	return c.grpcHistogram
+}
+
+// GRPCCheck builds a Check that speaks the standard grpc.health.v1.Health/Check protocol against
+// target, for use as an alternative to (or alongside) the HTTP /alwayshappy probe on
+// MeService/MeIngress/neighbour paths when the mesh is gRPC-native and an HTTP/1.1 probe isn't
+// representative of real traffic. name is the logical check name (e.g. "path_"+nodeName) used to
+// label metrics; unlike target (a podIP:port that churns with every pod reschedule), it is
+// bounded and matches the label used by checkPanicsCounterVec/intervalGaugeVec.
+func (c *Checker) GRPCCheck(name, target string) Check {
+	return func(ctx context.Context) string {
+		start := time.Now()
+		result := c.doGRPCCheck(ctx, target)
+
+		if c.grpcHistogram != nil {
+			c.grpcHistogram.WithLabelValues(name, result, protocolGRPC).Observe(time.Since(start).Seconds())
+		}
+
+		return result
+	}
+}
+
+// registerGRPCHealthChecks additionally registers gRPC health checks for MeService/MeIngress when
+// UseGRPCHealthChecks is enabled, alongside the HTTP /alwayshappy checks registered by
+// registerBuiltinChecks. Neighbour paths pick up gRPC the same way, in refreshNeighbourhood.
+func (c *Checker) registerGRPCHealthChecks() {
+	if !c.UseGRPCHealthChecks {
+		return
+	}
+
+	c.grpcLatencyHistogramVec(c.promRegistry, prometheus.DefBuckets)
+
+	if host := hostOnly(c.KubenurseServiceURL); host != "" {
+		name := "me_service_grpc"
+		c.RegisterCheck(name, c.GRPCCheck(name, host+":"+grpcPort), CheckOptions{CountsTowardAlive: true})
+	}
+
+	if host := hostOnly(c.KubenurseIngressURL); host != "" {
+		name := "me_ingress_grpc"
+		c.RegisterCheck(name, c.GRPCCheck(name, host+":"+grpcPort), CheckOptions{CountsTowardAlive: true})
+	}
+}
+
+// hostOnly extracts the hostname from a check target URL, discarding scheme/port/path.
+func hostOnly(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Hostname()
+}
+
+func (c *Checker) doGRPCCheck(ctx context.Context, target string) string {
+	conn, err := c.grpcConn(target)
+	if err != nil {
+		return fmt.Sprintf("%s: %v", errStr, err)
+	}
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Sprintf("%s: %v", errStr, err)
+	}
+
+	return grpcCheckResult(resp.GetStatus())
+}
+
+// grpcCheckResult converts a grpc.health.v1 serving status into the okStr/errStr vocabulary used
+// by every other Check, so callers don't need to know about the health service's status enum.
+func grpcCheckResult(status grpc_health_v1.HealthCheckResponse_ServingStatus) string {
+	if status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Sprintf("%s: status %s", errStr, status)
+	}
+
+	return okStr
+}
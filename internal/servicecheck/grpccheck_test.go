@@ -0,0 +1,45 @@
+package servicecheck
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestGRPCCheckResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		status grpc_health_v1.HealthCheckResponse_ServingStatus
+		want   string
+	}{
+		{name: "serving maps to ok", status: grpc_health_v1.HealthCheckResponse_SERVING, want: okStr},
+		{name: "not serving maps to error", status: grpc_health_v1.HealthCheckResponse_NOT_SERVING},
+		{name: "unknown maps to error", status: grpc_health_v1.HealthCheckResponse_UNKNOWN},
+		{name: "service unknown maps to error", status: grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grpcCheckResult(tt.status)
+
+			if tt.want != "" {
+				if got != tt.want {
+					t.Errorf("grpcCheckResult(%v) = %q, want %q", tt.status, got, tt.want)
+				}
+
+				return
+			}
+
+			if !strings.HasPrefix(got, errStr) {
+				t.Errorf("grpcCheckResult(%v) = %q, want a result prefixed with %q", tt.status, got, errStr)
+			}
+		})
+	}
+}
+
+func TestPodIPtoGRPCTarget(t *testing.T) {
+	if got, want := podIPtoGRPCTarget("10.0.0.1"), "10.0.0.1:9000"; got != want {
+		t.Errorf("podIPtoGRPCTarget() = %q, want %q", got, want)
+	}
+}
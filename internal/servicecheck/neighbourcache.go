@@ -0,0 +1,107 @@
+package servicecheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// neighbourCacheMaxStaleness bounds how long a cached neighbour list may be served after
+// getNeighbours last succeeded. Past this bound the neighbourhood is treated as unknown rather
+// than stale-but-usable, so a permanently broken API server eventually stops reporting path
+// checks against neighbours that may no longer exist.
+const neighbourCacheMaxStaleness = 10 * time.Minute
+
+// neighbourCache remembers the last successfully discovered neighbour list so a transient
+// getNeighbours error doesn't drop every path check for the cycle. This mirrors the "don't drop
+// working config on a transient API failure" pattern used elsewhere for fail-closed behaviour.
+type neighbourCache struct {
+	mu          sync.Mutex
+	neighbours  []Neighbour
+	lastUpdated time.Time
+}
+
+// get returns the cached neighbours and whether they are still within the staleness bound. It
+// returns ok=false once nothing has ever been cached or the cache is older than
+// neighbourCacheMaxStaleness.
+func (n *neighbourCache) get() (neighbours []Neighbour, ok bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.lastUpdated.IsZero() || time.Since(n.lastUpdated) > neighbourCacheMaxStaleness {
+		return nil, false
+	}
+
+	return n.neighbours, true
+}
+
+// set stores a freshly, successfully discovered neighbour list.
+func (n *neighbourCache) set(neighbours []Neighbour) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.neighbours = neighbours
+	n.lastUpdated = time.Now()
+}
+
+// neighbourDiscoveryErrorsCounter lazily creates (once) and returns the counter incremented every
+// time getNeighbours fails, regardless of whether a cached neighbour list could be served instead.
+func (c *Checker) neighbourDiscoveryErrorsCounter(promRegistry *prometheus.Registry) prometheus.Counter {
+	c.neighbourErrorsOnce.Do(func() {
+		c.neighbourErrors = prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "neighbour_discovery_errors_total",
+			Help:      "Number of times neighbour discovery (getNeighbours) failed, independent of whether a cached neighbour list was served instead.",
+		})
+
+		promRegistry.MustRegister(c.neighbourErrors)
+	})
+
+	return c.neighbourErrors
+}
+
+// staleGaugeMetric lazily creates (once) and returns the gauge reporting whether the current
+// neighbourhood was served from the last-known-good cache (1) or from a fresh discovery (0). This
+// is a single gauge, not a vector: one discovery cycle either succeeds or falls back to cache for
+// the whole neighbourhood at once, so there is no per-check value to label it by.
+func (c *Checker) staleGaugeMetric(promRegistry *prometheus.Registry) prometheus.Gauge {
+	c.staleGaugeOnce.Do(func() {
+		c.staleGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "neighbour_stale",
+			Help:      "1 if the neighbour list was served from the last-known-good cache because discovery failed, 0 otherwise.",
+		})
+
+		promRegistry.MustRegister(c.staleGauge)
+	})
+
+	return c.staleGauge
+}
+
+// discoverNeighbours runs getNeighbours and falls back to the last-known-good cached neighbour
+// list on error, so transient kube-apiserver hiccups don't wipe the entire neighbourhood signal.
+// It returns the neighbours to check, whether the result is stale (served from cache), and
+// whether discovery itself succeeded.
+func (c *Checker) discoverNeighbours(ctx context.Context, promRegistry *prometheus.Registry) (neighbours []Neighbour, stale bool, err error) {
+	neighbours, err = c.getNeighbours(ctx, c.KubenurseNamespace, c.NeighbourFilter)
+	if err == nil {
+		c.neighbourCache.set(neighbours)
+		c.staleGaugeMetric(promRegistry).Set(0)
+
+		return neighbours, false, nil
+	}
+
+	c.neighbourDiscoveryErrorsCounter(promRegistry).Inc()
+
+	cached, ok := c.neighbourCache.get()
+	if !ok {
+		c.staleGaugeMetric(promRegistry).Set(0)
+		return nil, false, err
+	}
+
+	c.staleGaugeMetric(promRegistry).Set(1)
+
+	return cached, true, err
+}
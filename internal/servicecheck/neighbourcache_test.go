@@ -0,0 +1,54 @@
+package servicecheck
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNeighbourCacheGetSet(t *testing.T) {
+	t.Run("get on an empty cache reports not ok", func(t *testing.T) {
+		c := &neighbourCache{}
+
+		if _, ok := c.get(); ok {
+			t.Error("get() on an empty cache = ok, want not ok")
+		}
+	})
+
+	t.Run("get returns what was set", func(t *testing.T) {
+		c := &neighbourCache{}
+		want := []Neighbour{{NodeName: "node-a"}, {NodeName: "node-b"}}
+
+		c.set(want)
+
+		got, ok := c.get()
+		if !ok {
+			t.Fatal("get() = not ok, want ok right after set")
+		}
+
+		if len(got) != len(want) {
+			t.Fatalf("get() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("get reports not ok once past neighbourCacheMaxStaleness", func(t *testing.T) {
+		c := &neighbourCache{
+			neighbours:  []Neighbour{{NodeName: "node-a"}},
+			lastUpdated: time.Now().Add(-neighbourCacheMaxStaleness - time.Second),
+		}
+
+		if _, ok := c.get(); ok {
+			t.Error("get() past neighbourCacheMaxStaleness = ok, want not ok")
+		}
+	})
+
+	t.Run("get still reports ok just within neighbourCacheMaxStaleness", func(t *testing.T) {
+		c := &neighbourCache{
+			neighbours:  []Neighbour{{NodeName: "node-a"}},
+			lastUpdated: time.Now().Add(-neighbourCacheMaxStaleness + time.Second),
+		}
+
+		if _, ok := c.get(); !ok {
+			t.Error("get() just within neighbourCacheMaxStaleness = not ok, want ok")
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package servicecheck
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// checkPanicsCounterVec lazily creates (once) and returns the counter vector incremented every
+// time a check panics instead of returning normally.
+func (c *Checker) checkPanicsCounterVec(promRegistry *prometheus.Registry) *prometheus.CounterVec {
+	c.checkPanicsOnce.Do(func() {
+		c.checkPanics = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "check_panics_total",
+			Help:      "Number of times a check function panicked instead of returning normally.",
+		}, []string{"check"})
+
+		promRegistry.MustRegister(c.checkPanics)
+	})
+
+	return c.checkPanics
+}
+
+// recoverCheck runs fn, converting a panic into an errStr result instead of letting it unwind and
+// bring down the calling goroutine. This mirrors Kubernetes' runtime.HandleCrash pattern: a panic
+// inside doRequest, an HTTP tracer callback, or a custom check function must never take down the
+// whole kubenurse pod or leave a sync.WaitGroup counter unbalanced.
+func (c *Checker) recoverCheck(name string, fn Check) (result string) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("recovered from panic in check", "check", name, "panic", r)
+			c.checkPanicsCounterVec(c.promRegistry).WithLabelValues(name).Inc()
+			result = fmt.Sprintf("%s: panic: %v", errStr, r)
+		}
+	}()
+
+	return fn(context.WithValue(context.Background(), kubenurseTypeKey{}, name))
+}
+
+// runScheduledTick runs one tick of a scheduleCheck loop, recovering from any panic in it (not
+// just in fn, which recoverCheck already guards) so a bug in the tick bookkeeping itself can
+// never take down the detached scheduleCheck goroutine, and therefore the process.
+func (c *Checker) runScheduledTick(name string, fn Check, interval *adaptiveInterval) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("recovered from panic in scheduleCheck tick", "check", name, "panic", r)
+			c.checkPanicsCounterVec(c.promRegistry).WithLabelValues(name).Inc()
+			interval.record(false)
+		}
+	}()
+
+	result := c.recoverCheck(name, fn)
+	c.storeResult(name, result)
+	interval.record(result == okStr || result == skippedStr)
+}
+
+// runNeighbourhoodTick runs one tick of the neighbourhood discovery loop, recovering from any
+// panic in refreshNeighbourhood (and anything it calls, like getNeighbours or filterNeighbours)
+// so it can never take down the detached scheduleNeighbourhood goroutine.
+func (c *Checker) runNeighbourhoodTick(stop <-chan struct{}, paths map[string]chan struct{}, targets map[string]string, base time.Duration, interval *adaptiveInterval) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("recovered from panic in neighbourhood discovery", "panic", r)
+			c.neighbourDiscoveryErrorsCounter(c.promRegistry).Inc()
+			interval.record(false)
+		}
+	}()
+
+	interval.record(c.refreshNeighbourhood(stop, paths, targets, base))
+}
+
+// runScheduledSupervised runs RunScheduled and, should its synchronous setup ever panic, logs the
+// panic and restarts it instead of letting the panic take down the process. Every attempt gets
+// its own generation stop channel which is always closed before a restart, so a respawned
+// generation can never run alongside the goroutines (scheduleCheck loops, the neighbourhood
+// discovery loop) started by the generation that just panicked. It returns once c.stop is closed.
+func (c *Checker) runScheduledSupervised(d time.Duration) {
+	for {
+		select {
+		case <-c.stop:
+			return
+		default:
+		}
+
+		genStop := make(chan struct{})
+		stopped := c.runScheduledOnce(mergeStop(c.stop, genStop), d)
+		close(genStop)
+
+		if stopped {
+			return
+		}
+
+		slog.Error("kubenurse scheduler loop panicked, restarting", "check_interval", d)
+	}
+}
+
+// runScheduledOnce runs the runScheduled body once with the given generation stop channel,
+// recovering from any panic in its synchronous setup. It returns true if stop was closed (normal
+// shutdown) and false if it returned because of a recovered panic.
+//
+// Note: the per-check and per-neighbour work itself runs in detached goroutines (scheduleCheck,
+// scheduleNeighbourhood's loop) that this recover() cannot see into — those loops protect
+// themselves individually, see runScheduledTick and runNeighbourhoodTick.
+func (c *Checker) runScheduledOnce(stop <-chan struct{}, d time.Duration) (stopped bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("recovered from panic in scheduler loop", "panic", r)
+			stopped = false
+		}
+	}()
+
+	c.runScheduled(stop, d)
+
+	return true
+}
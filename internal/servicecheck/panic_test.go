@@ -0,0 +1,61 @@
+package servicecheck
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestRecoverCheckConvertsPanicToError(t *testing.T) {
+	c := &Checker{promRegistry: prometheus.NewRegistry()}
+
+	panicking := Check(func(ctx context.Context) string {
+		panic("boom")
+	})
+
+	result := c.recoverCheck("test", panicking)
+
+	if !strings.HasPrefix(result, errStr) {
+		t.Errorf("recoverCheck() = %q, want a result prefixed with %q", result, errStr)
+	}
+}
+
+func TestRecoverCheckPassesThroughNormalResult(t *testing.T) {
+	c := &Checker{promRegistry: prometheus.NewRegistry()}
+
+	ok := Check(func(ctx context.Context) string {
+		return okStr
+	})
+
+	if result := c.recoverCheck("test", ok); result != okStr {
+		t.Errorf("recoverCheck() = %q, want %q", result, okStr)
+	}
+}
+
+func TestRunScheduledTickRecoversPanicAndBacksOff(t *testing.T) {
+	c := &Checker{promRegistry: prometheus.NewRegistry()}
+
+	panicking := Check(func(ctx context.Context) string {
+		panic("boom")
+	})
+
+	interval := newAdaptiveInterval(5 * time.Minute)
+
+	// Must not panic and must still update interval/LastCheckResult, end to end.
+	c.runScheduledTick("test", panicking, interval)
+
+	if got := interval.period(); got != minRetryInterval {
+		t.Errorf("period() after a panicking tick = %s, want %s (the failure fast-retry period)", got, minRetryInterval)
+	}
+
+	c.resultsMu.Lock()
+	result, _ := c.LastCheckResult["test"].(string)
+	c.resultsMu.Unlock()
+
+	if !strings.HasPrefix(result, errStr) {
+		t.Errorf("LastCheckResult[\"test\"] = %q, want a result prefixed with %q", result, errStr)
+	}
+}
@@ -0,0 +1,222 @@
+package servicecheck
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CheckOptions configures a single registered check: how long it is allowed to run, how often it
+// is scheduled, and whether it gates the /alive handler (see Alive).
+type CheckOptions struct {
+	// Timeout bounds a single run of the check. Zero means the httpClient's own timeout applies.
+	Timeout time.Duration
+	// Interval overrides the base scheduling interval passed to RunScheduled for this check only.
+	// Zero means use the base interval.
+	Interval time.Duration
+	// CountsTowardAlive controls whether a failing result for this check should fail the /alive
+	// handler, or merely be reported in the metrics/LastCheckResult.
+	CountsTowardAlive bool
+}
+
+// registeredCheck pairs a Check function with the CheckOptions it was registered with.
+type registeredCheck struct {
+	fn   Check
+	opts CheckOptions
+}
+
+// RegisterCheck adds (or replaces) a named check that Run and RunScheduled will execute
+// alongside the built-in checks. This is the extension point operators use to probe additional
+// dependencies (CoreDNS, kubelet, a specific Service, an out-of-cluster dependency) without
+// editing Run.
+func (c *Checker) RegisterCheck(name string, fn Check, opts CheckOptions) {
+	c.checks.Store(name, &registeredCheck{fn: fn, opts: opts})
+}
+
+// UnregisterCheck removes a previously registered check. It is a no-op if name isn't registered.
+func (c *Checker) UnregisterCheck(name string) {
+	c.checks.Delete(name)
+}
+
+// registerBuiltinChecks registers the fixed checks that have always shipped with kubenurse, so
+// Run and RunScheduled can treat them the same way as any operator-registered check.
+func (c *Checker) registerBuiltinChecks() {
+	c.RegisterCheck(APIServerDirect, c.APIServerDirect, CheckOptions{CountsTowardAlive: true})
+	c.RegisterCheck(APIServerDNS, c.APIServerDNS, CheckOptions{CountsTowardAlive: true})
+	c.RegisterCheck(APIServerLoadBalancer, c.APIServerLoadBalancer, CheckOptions{CountsTowardAlive: false})
+	c.RegisterCheck(meIngress, c.MeIngress, CheckOptions{CountsTowardAlive: true})
+	c.RegisterCheck(meService, c.MeService, CheckOptions{CountsTowardAlive: true})
+}
+
+// Alive reports whether every check registered with CountsTowardAlive last reported okStr or
+// skippedStr. This is the signal an /alive handler should gate readiness on: checks that opted
+// out of CountsTowardAlive (e.g. APIServerLoadBalancer, which probes a dependency outside the
+// pod's own control) are still run and reported, but never fail it. A check that hasn't run yet
+// counts as not alive.
+func (c *Checker) Alive() bool {
+	alive := true
+
+	c.forEachCheck(func(name string, rc *registeredCheck) {
+		if !rc.opts.CountsTowardAlive {
+			return
+		}
+
+		c.resultsMu.Lock()
+		result, ok := c.LastCheckResult[name].(string)
+		c.resultsMu.Unlock()
+
+		if !ok || (result != okStr && result != skippedStr) {
+			alive = false
+		}
+	})
+
+	return alive
+}
+
+// forEachCheck calls fn for every currently registered check. The iteration order is
+// unspecified, matching sync.Map semantics.
+func (c *Checker) forEachCheck(fn func(name string, rc *registeredCheck)) {
+	c.checks.Range(func(key, value any) bool {
+		name, _ := key.(string)
+		rc, _ := value.(*registeredCheck)
+		fn(name, rc)
+
+		return true
+	})
+}
+
+// withTimeout wraps check so a run is cancelled after opts.Timeout, if set.
+func withTimeout(check Check, timeout time.Duration) Check {
+	if timeout <= 0 {
+		return check
+	}
+
+	return func(ctx context.Context) string {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return check(ctx)
+	}
+}
+
+// checkDefinition is the shape of a single entry in the KUBENURSE_CHECKS_FILE check definition
+// file: a simple HTTP(S) probe an operator can add without writing Go code.
+type checkDefinition struct {
+	Name              string `json:"name" yaml:"name"`
+	URL               string `json:"url" yaml:"url"`
+	Method            string `json:"method" yaml:"method"`
+	ExpectedStatus    int    `json:"expectedStatus" yaml:"expectedStatus"`
+	CountsTowardAlive bool   `json:"countsTowardAlive" yaml:"countsTowardAlive"`
+	TLSSkipVerify     bool   `json:"tlsSkipVerify" yaml:"tlsSkipVerify"`
+}
+
+// loadChecksFile reads the check definitions pointed at by KUBENURSE_CHECKS_FILE (YAML or JSON,
+// detected from the file content by jsonLike) and registers one HTTP check per entry.
+func (c *Checker) loadChecksFile() error {
+	path := os.Getenv("KUBENURSE_CHECKS_FILE")
+	if path == "" {
+		return nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read KUBENURSE_CHECKS_FILE %q: %w", path, err)
+	}
+
+	var defs []checkDefinition
+
+	unmarshal := yaml.Unmarshal
+	if jsonLike(raw) {
+		unmarshal = json.Unmarshal
+	}
+
+	if err := unmarshal(raw, &defs); err != nil {
+		return fmt.Errorf("cannot parse KUBENURSE_CHECKS_FILE %q: %w", path, err)
+	}
+
+	for _, def := range defs {
+		if def.Name == "" || def.URL == "" {
+			return fmt.Errorf("invalid check definition in %q: name and url are required", path)
+		}
+
+		c.RegisterCheck("custom_"+def.Name, c.httpCheck(def), CheckOptions{CountsTowardAlive: def.CountsTowardAlive})
+	}
+
+	return nil
+}
+
+// jsonLike reports whether raw looks like a JSON document, so loadChecksFile can accept either
+// JSON or YAML without requiring operators to name the file accordingly.
+func jsonLike(raw []byte) bool {
+	for _, b := range raw {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[', '{':
+			return true
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// httpCheck builds a Check function that performs a single HTTP request per the given
+// definition and reports okStr only if the response status matches ExpectedStatus.
+func (c *Checker) httpCheck(def checkDefinition) Check {
+	method := def.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	expected := def.ExpectedStatus
+	if expected == 0 {
+		expected = http.StatusOK
+	}
+
+	httpClient := c.httpClient
+	if def.TLSSkipVerify {
+		httpClient = c.insecureHTTPClient()
+	}
+
+	return func(ctx context.Context) string {
+		req, err := http.NewRequestWithContext(ctx, method, def.URL, nil)
+		if err != nil {
+			return fmt.Sprintf("%s: %v", errStr, err)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Sprintf("%s: %v", errStr, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != expected {
+			return fmt.Sprintf("%s: unexpected status %d, expected %d", errStr, resp.StatusCode, expected)
+		}
+
+		return okStr
+	}
+}
+
+// insecureHTTPClient lazily creates (once) a minimal http.Client that skips TLS verification,
+// for check definitions that explicitly opt into tlsSkipVerify.
+func (c *Checker) insecureHTTPClient() *http.Client {
+	c.insecureHTTPClientOnce.Do(func() {
+		c.insecureClient = &http.Client{
+			Timeout: c.httpClient.Timeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: true}, //nolint:gosec // explicit opt-in via tlsSkipVerify
+			},
+		}
+	})
+
+	return c.insecureClient
+}
@@ -0,0 +1,146 @@
+package servicecheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONLike(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want bool
+	}{
+		{name: "json array", raw: `[{"name":"a","url":"http://a"}]`, want: true},
+		{name: "json array with leading whitespace", raw: "  \n\t[{}]", want: true},
+		{name: "yaml list", raw: "- name: a\n  url: http://a\n", want: false},
+		{name: "empty", raw: "", want: false},
+		{name: "whitespace only", raw: "   \n", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonLike([]byte(tt.raw)); got != tt.want {
+				t.Errorf("jsonLike(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadChecksFile(t *testing.T) {
+	t.Run("no file configured is a no-op", func(t *testing.T) {
+		t.Setenv("KUBENURSE_CHECKS_FILE", "")
+
+		c := &Checker{}
+		if err := c.loadChecksFile(); err != nil {
+			t.Fatalf("loadChecksFile() = %v, want nil", err)
+		}
+	})
+
+	t.Run("registers one check per JSON entry", func(t *testing.T) {
+		path := writeChecksFile(t, `[
+			{"name": "coredns", "url": "http://coredns.kube-system:53", "countsTowardAlive": true},
+			{"name": "kubelet", "url": "https://kubelet:10250", "method": "HEAD", "expectedStatus": 200}
+		]`)
+		t.Setenv("KUBENURSE_CHECKS_FILE", path)
+
+		c := &Checker{}
+		if err := c.loadChecksFile(); err != nil {
+			t.Fatalf("loadChecksFile() = %v, want nil", err)
+		}
+
+		for _, name := range []string{"custom_coredns", "custom_kubelet"} {
+			if _, ok := c.checks.Load(name); !ok {
+				t.Errorf("expected check %q to be registered", name)
+			}
+		}
+	})
+
+	t.Run("registers one check per YAML entry", func(t *testing.T) {
+		path := writeChecksFile(t, "- name: coredns\n  url: http://coredns.kube-system:53\n")
+		t.Setenv("KUBENURSE_CHECKS_FILE", path)
+
+		c := &Checker{}
+		if err := c.loadChecksFile(); err != nil {
+			t.Fatalf("loadChecksFile() = %v, want nil", err)
+		}
+
+		if _, ok := c.checks.Load("custom_coredns"); !ok {
+			t.Error("expected check \"custom_coredns\" to be registered")
+		}
+	})
+
+	t.Run("rejects an entry missing name or url", func(t *testing.T) {
+		path := writeChecksFile(t, `[{"url": "http://coredns.kube-system:53"}]`)
+		t.Setenv("KUBENURSE_CHECKS_FILE", path)
+
+		c := &Checker{}
+		if err := c.loadChecksFile(); err == nil {
+			t.Fatal("loadChecksFile() = nil, want an error for the missing name")
+		}
+	})
+
+	t.Run("errors on an unreadable path", func(t *testing.T) {
+		t.Setenv("KUBENURSE_CHECKS_FILE", filepath.Join(t.TempDir(), "does-not-exist.json"))
+
+		c := &Checker{}
+		if err := c.loadChecksFile(); err == nil {
+			t.Fatal("loadChecksFile() = nil, want an error for the missing file")
+		}
+	})
+}
+
+func TestAlive(t *testing.T) {
+	t.Run("true when every CountsTowardAlive check is ok", func(t *testing.T) {
+		c := &Checker{}
+		c.RegisterCheck("a", nil, CheckOptions{CountsTowardAlive: true})
+		c.RegisterCheck("b", nil, CheckOptions{CountsTowardAlive: false})
+		c.storeResult("a", okStr)
+		c.storeResult("b", "error: boom")
+
+		if !c.Alive() {
+			t.Error("Alive() = false, want true: the failing check doesn't count toward alive")
+		}
+	})
+
+	t.Run("skippedStr counts as alive", func(t *testing.T) {
+		c := &Checker{}
+		c.RegisterCheck("a", nil, CheckOptions{CountsTowardAlive: true})
+		c.storeResult("a", skippedStr)
+
+		if !c.Alive() {
+			t.Error("Alive() = false, want true for a skipped check")
+		}
+	})
+
+	t.Run("false when a CountsTowardAlive check is failing", func(t *testing.T) {
+		c := &Checker{}
+		c.RegisterCheck("a", nil, CheckOptions{CountsTowardAlive: true})
+		c.storeResult("a", "error: boom")
+
+		if c.Alive() {
+			t.Error("Alive() = true, want false")
+		}
+	})
+
+	t.Run("false when a CountsTowardAlive check has never run", func(t *testing.T) {
+		c := &Checker{}
+		c.RegisterCheck("a", nil, CheckOptions{CountsTowardAlive: true})
+
+		if c.Alive() {
+			t.Error("Alive() = true, want false for a check with no result yet")
+		}
+	})
+}
+
+func writeChecksFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "checks.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("cannot write checks file: %v", err)
+	}
+
+	return path
+}
@@ -0,0 +1,259 @@
+package servicecheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// minRetryInterval is the fast-retry period a check drops to as soon as it starts failing.
+const minRetryInterval = 10 * time.Second
+
+// adaptiveInterval tracks the current effective scheduling period for a single check target.
+// It starts out at base. As soon as a check fails it drops to minRetryInterval so a transient
+// failure is retried quickly; further consecutive failures back off exponentially up to base
+// again, so a check that is persistently down doesn't get hammered forever. A single okStr
+// result resets it straight back to base.
+type adaptiveInterval struct {
+	mu      sync.Mutex
+	base    time.Duration
+	current time.Duration
+	failing bool
+}
+
+func newAdaptiveInterval(base time.Duration) *adaptiveInterval {
+	return &adaptiveInterval{base: base, current: base}
+}
+
+// period returns the interval to wait before the next run.
+func (a *adaptiveInterval) period() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.current
+}
+
+// record updates the interval based on the outcome of the last run.
+func (a *adaptiveInterval) record(ok bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if ok {
+		a.current = a.base
+		a.failing = false
+
+		return
+	}
+
+	if !a.failing {
+		a.failing = true
+		a.current = minRetryInterval
+
+		return
+	}
+
+	a.current *= 2
+	if a.current > a.base {
+		a.current = a.base
+	}
+}
+
+// intervalGaugeVec lazily creates (once) and returns the gauge vector used to expose the current
+// effective interval of every scheduled check, so operators can see which paths are in
+// "fast retry" mode.
+func (c *Checker) intervalGaugeVec(promRegistry *prometheus.Registry) *prometheus.GaugeVec {
+	c.intervalGaugeOnce.Do(func() {
+		c.intervalGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "check_interval_seconds",
+			Help:      "Effective scheduling interval of a check, in seconds. Drops when a check is failing and recovers to the base interval once it succeeds again.",
+		}, []string{"check"})
+
+		promRegistry.MustRegister(c.intervalGauge)
+	})
+
+	return c.intervalGauge
+}
+
+// refreshNeighbourhood discovers the current neighbours and reconciles the set of running
+// per-neighbour scheduleCheck goroutines (keyed by node name) against it, starting one for every
+// newly seen neighbour and stopping one for every neighbour that is gone. It returns whether
+// discovery itself succeeded, which feeds the neighbour-discovery adaptive interval.
+func (c *Checker) refreshNeighbourhood(stop <-chan struct{}, paths map[string]chan struct{}, targets map[string]string, base time.Duration) bool {
+	neighbours, stale, err := c.discoverNeighbours(context.Background(), c.promRegistry)
+	if err != nil && !stale {
+		c.storeResult(NeighbourhoodState, err.Error())
+
+		// No usable cached neighbour list: stop every running path check.
+		for nodeName, pathStop := range paths {
+			close(pathStop)
+			delete(paths, nodeName)
+
+			if target, ok := targets[nodeName]; ok {
+				c.closeGRPCConn(target)
+				delete(targets, nodeName)
+			}
+		}
+
+		return false
+	}
+
+	// Even served from cache, discovery itself is still failing: keep the adaptive interval in
+	// fast-retry mode so we notice as soon as the API server recovers.
+	discoveryHealthy := err == nil
+
+	if stale {
+		c.storeResult(NeighbourhoodState, "stale: "+err.Error())
+	} else {
+		c.storeResult(NeighbourhoodState, okStr)
+	}
+
+	c.storeResult(Neighbourhood, neighbours)
+
+	if c.NeighbourLimit > 0 && len(neighbours) > c.NeighbourLimit {
+		neighbours = c.filterNeighbours(neighbours)
+	}
+
+	seen := make(map[string]struct{}, len(neighbours))
+
+	for _, neighbour := range neighbours {
+		seen[neighbour.NodeName] = struct{}{}
+
+		if _, running := paths[neighbour.NodeName]; running {
+			continue
+		}
+
+		neighbour := neighbour
+		name := "path_" + neighbour.NodeName
+		pathStop := make(chan struct{})
+		paths[neighbour.NodeName] = pathStop
+
+		check := Check(func(ctx context.Context) string {
+			return c.doRequest(ctx, podIPtoURL(neighbour.PodIP, c.UseTLS), true)
+		})
+		if c.UseGRPCHealthChecks {
+			target := podIPtoGRPCTarget(neighbour.PodIP)
+			targets[neighbour.NodeName] = target
+			check = c.GRPCCheck(name, target)
+		}
+
+		stopForPath := mergeStop(stop, pathStop)
+		go c.scheduleCheck(stopForPath, name, check, base, c.promRegistry)
+	}
+
+	for nodeName, pathStop := range paths {
+		if _, ok := seen[nodeName]; ok {
+			continue
+		}
+
+		close(pathStop)
+		delete(paths, nodeName)
+
+		if target, ok := targets[nodeName]; ok {
+			c.closeGRPCConn(target)
+			delete(targets, nodeName)
+		}
+	}
+
+	return discoveryHealthy
+}
+
+// mergeStop returns a channel that closes as soon as either a or b closes, so a per-neighbour
+// check goroutine can be stopped either by RunScheduled shutting down entirely or by the
+// neighbour disappearing on its own.
+func mergeStop(a, b <-chan struct{}) <-chan struct{} {
+	merged := make(chan struct{})
+
+	go func() {
+		defer close(merged)
+
+		select {
+		case <-a:
+		case <-b:
+		}
+	}()
+
+	return merged
+}
+
+// storeResult safely records the result of a single check in LastCheckResult (used by the
+// /alive handler). It does not touch any metric.
+// result is any, not string, because it is also used to store Neighbourhood's []Neighbour value.
+func (c *Checker) storeResult(name string, result any) {
+	c.resultsMu.Lock()
+	defer c.resultsMu.Unlock()
+
+	if c.LastCheckResult == nil {
+		c.LastCheckResult = make(map[string]any)
+	}
+
+	c.LastCheckResult[name] = result
+}
+
+// scheduleCheck runs fn in a loop on its own adaptive interval until stop is closed, recording
+// its result and effective interval after every run. Every check target gets its own instance
+// of this loop so a single flaky check cannot slow down the reporting of the others.
+func (c *Checker) scheduleCheck(stop <-chan struct{}, name string, fn Check, base time.Duration, promRegistry *prometheus.Registry) {
+	interval := newAdaptiveInterval(base)
+	gauge := c.intervalGaugeVec(promRegistry).WithLabelValues(name)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-timer.C:
+			c.runScheduledTick(name, fn, interval)
+
+			period := interval.period()
+			gauge.Set(period.Seconds())
+			timer.Reset(period)
+		}
+	}
+}
+
+// scheduleNeighbourhood runs neighbour discovery on its own adaptive interval and keeps one
+// scheduleCheck goroutine per currently known neighbour path, starting one as soon as a new
+// neighbour appears and stopping it as soon as the neighbour disappears.
+func (c *Checker) scheduleNeighbourhood(stop <-chan struct{}, base time.Duration) {
+	if c.SkipCheckNeighbourhood {
+		c.storeResult(NeighbourhoodState, skippedStr)
+		return
+	}
+
+	go func() {
+		interval := newAdaptiveInterval(base)
+		gauge := c.intervalGaugeVec(c.promRegistry).WithLabelValues(NeighbourhoodState)
+
+		paths := make(map[string]chan struct{})
+		targets := make(map[string]string)
+
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-stop:
+				for nodeName, pathStop := range paths {
+					close(pathStop)
+
+					if target, ok := targets[nodeName]; ok {
+						c.closeGRPCConn(target)
+					}
+				}
+
+				return
+			case <-timer.C:
+				c.runNeighbourhoodTick(stop, paths, targets, base, interval)
+
+				period := interval.period()
+				gauge.Set(period.Seconds())
+				timer.Reset(period)
+			}
+		}
+	}()
+}
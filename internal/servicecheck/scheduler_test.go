@@ -0,0 +1,67 @@
+package servicecheck
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdaptiveIntervalRecord(t *testing.T) {
+	base := 5 * time.Minute
+
+	tests := []struct {
+		name    string
+		results []bool // sequence of ok/error results fed into record
+		want    time.Duration
+	}{
+		{
+			name:    "stays at base while healthy",
+			results: []bool{true, true, true},
+			want:    base,
+		},
+		{
+			name:    "drops to the fast retry period on the first failure",
+			results: []bool{false},
+			want:    minRetryInterval,
+		},
+		{
+			name:    "backs off exponentially on repeated failures",
+			results: []bool{false, false, false},
+			want:    40 * time.Second, // 10s -> 20s -> 40s
+		},
+		{
+			name: "backoff is capped at base",
+			// 10s -> 20s -> 40s -> 80s -> 160s -> 320s, clamped down to the 5m base
+			results: []bool{false, false, false, false, false, false},
+			want:    base,
+		},
+		{
+			name:    "a single success resets straight back to base",
+			results: []bool{false, false, true},
+			want:    base,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval := newAdaptiveInterval(base)
+
+			for _, ok := range tt.results {
+				interval.record(ok)
+			}
+
+			if got := interval.period(); got != tt.want {
+				t.Errorf("period() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdaptiveIntervalStartsAtBase(t *testing.T) {
+	base := 5 * time.Minute
+
+	interval := newAdaptiveInterval(base)
+
+	if got := interval.period(); got != base {
+		t.Errorf("period() on a fresh interval = %s, want %s", got, base)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,12 +22,15 @@ const (
 	errStr           = "error"
 	skippedStr       = "skipped"
 	MetricsNamespace = "kubenurse"
+
+	// APIServerLoadBalancer is the check/metric label for the external API server reachability check.
+	APIServerLoadBalancer = "path_apiserver_loadbalancer"
 )
 
 // New configures the checker with a httpClient and a cache timeout for check
 // results. Other parameters of the Checker struct need to be configured separately.
 func New(_ context.Context, cl client.Client, promRegistry *prometheus.Registry,
-	allowUnschedulable bool, cacheTTL time.Duration, durationHistogramBuckets []float64) (*Checker, error) {
+	allowUnschedulable bool, cacheTTL time.Duration, durationHistogramBuckets []float64, tlsProfile string) (*Checker, error) {
 	// setup http transport
 	tlsConfig, err := generateTLSConfig(os.Getenv("KUBENURSE_EXTRA_CA"))
 	if err != nil {
@@ -36,6 +40,14 @@ func New(_ context.Context, cl client.Client, promRegistry *prometheus.Registry,
 		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
 	}
 
+	if tlsProfile == "" {
+		tlsProfile = os.Getenv("KUBENURSE_TLS_PROFILE")
+	}
+
+	if err := applyTLSProfile(tlsConfig, tlsProfile); err != nil {
+		return nil, fmt.Errorf("cannot apply KUBENURSE_TLS_PROFILE %q: %w", tlsProfile, err)
+	}
+
 	tlsConfig.InsecureSkipVerify = os.Getenv("KUBENURSE_INSECURE") == "true"
 	dialer := &net.Dialer{
 		Timeout:   30 * time.Second,
@@ -58,13 +70,31 @@ func New(_ context.Context, cl client.Client, promRegistry *prometheus.Registry,
 		Transport: withHttptrace(promRegistry, transport, durationHistogramBuckets),
 	}
 
-	return &Checker{
-		allowUnschedulable: allowUnschedulable,
-		client:             cl,
-		httpClient:         httpClient,
-		cacheTTL:           cacheTTL,
-		stop:               make(chan struct{}),
-	}, nil
+	apiServerLBURL := strings.TrimSuffix(os.Getenv("KUBENURSE_APISERVER_LB_URL"), "/")
+	if apiServerLBURL == "" {
+		apiServerLBURL = discoverAPIServerLBURL()
+	}
+
+	checker := &Checker{
+		allowUnschedulable:  allowUnschedulable,
+		client:              cl,
+		httpClient:          httpClient,
+		cacheTTL:            cacheTTL,
+		stop:                make(chan struct{}),
+		APIServerLBURL:      apiServerLBURL,
+		promRegistry:        promRegistry,
+		tlsProfile:          tlsProfile,
+		UseGRPCHealthChecks: os.Getenv("KUBENURSE_GRPC_HEALTH_CHECKS") == "true",
+	}
+
+	checker.registerBuiltinChecks()
+	checker.registerGRPCHealthChecks()
+
+	if err := checker.loadChecksFile(); err != nil {
+		return nil, err
+	}
+
+	return checker, nil
 }
 
 // Run runs all servicechecks and returns the result togeter with a boolean which indicates success. The cache
@@ -75,7 +105,8 @@ func (c *Checker) Run() {
 
 	wg := sync.WaitGroup{}
 
-	// Cache result (used for /alive handler)
+	// Cache result (used for /alive handler). Guarded by resultsMu since RunScheduled's
+	// storeResult may be mutating LastCheckResult concurrently on the same Checker.
 	defer func() {
 		res := make(map[string]any)
 
@@ -86,28 +117,34 @@ func (c *Checker) Run() {
 			return true
 		})
 
+		c.resultsMu.Lock()
 		c.LastCheckResult = res
+		c.resultsMu.Unlock()
 	}()
 
-	wg.Add(4)
+	c.forEachCheck(func(name string, rc *registeredCheck) {
+		wg.Add(1)
 
-	go c.measure(&wg, &result, c.APIServerDirect, APIServerDirect)
-	go c.measure(&wg, &result, c.APIServerDNS, APIServerDNS)
-	go c.measure(&wg, &result, c.MeIngress, meIngress)
-	go c.measure(&wg, &result, c.MeService, meService)
+		go c.measure(&wg, &result, withTimeout(rc.fn, rc.opts.Timeout), name)
+	})
 
 	if c.SkipCheckNeighbourhood {
 		result.Store(NeighbourhoodState, skippedStr)
 		return
 	}
 
-	neighbours, err := c.getNeighbours(context.Background(), c.KubenurseNamespace, c.NeighbourFilter)
-	if err != nil {
+	neighbours, stale, err := c.discoverNeighbours(context.Background(), c.promRegistry)
+	if err != nil && !stale {
 		result.Store(NeighbourhoodState, err.Error())
 		return
 	}
 
-	result.Store(NeighbourhoodState, okStr)
+	if stale {
+		result.Store(NeighbourhoodState, "stale: "+err.Error())
+	} else {
+		result.Store(NeighbourhoodState, okStr)
+	}
+
 	result.Store(Neighbourhood, neighbours)
 
 	if c.NeighbourLimit > 0 && len(neighbours) > c.NeighbourLimit {
@@ -117,30 +154,49 @@ func (c *Checker) Run() {
 	wg.Add((len(neighbours)))
 
 	for _, neighbour := range neighbours {
-		check := func(ctx context.Context) string {
+		name := "path_" + neighbour.NodeName
+
+		check := Check(func(ctx context.Context) string {
 			return c.doRequest(ctx, podIPtoURL(neighbour.PodIP, c.UseTLS), true)
+		})
+		if c.UseGRPCHealthChecks {
+			check = c.GRPCCheck(name, podIPtoGRPCTarget(neighbour.PodIP))
 		}
 
-		go c.measure(&wg, &result, check, "path_"+neighbour.NodeName)
+		go c.measure(&wg, &result, check, name)
 	}
 
 	wg.Wait()
 }
 
-// RunScheduled runs the checks in the specified interval which can be used to keep the metrics up-to-date. This
-// function does not return until StopScheduled is called.
+// RunScheduled runs the checks on a per-check adaptive interval which can be used to keep the
+// metrics up-to-date: a check that returns okStr is re-run every d (the base interval), while a
+// check that errors is retried on a much shorter interval that backs off exponentially up to d
+// while it keeps failing, and goes back to d as soon as it succeeds again. Every check target (the fixed checks plus every neighbour path) gets its own
+// ticker/state, so a flaky neighbour can't slow down reporting for the rest of the cluster. A
+// watchdog recovers the scheduler loop itself should it ever panic. This function does not return
+// until StopScheduled is called.
 func (c *Checker) RunScheduled(d time.Duration) {
-	ticker := time.NewTicker(d)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			c.Run()
-		case <-c.stop:
-			return
+	c.runScheduledSupervised(d)
+}
+
+// runScheduled is the unsupervised body of RunScheduled, split out so runScheduledSupervised can
+// recover from a panic in this synchronous setup and restart it. stop is this attempt's
+// generation stop channel (derived from c.stop): closing it, and only it, tears down every
+// goroutine started by this call without touching a subsequent generation's.
+func (c *Checker) runScheduled(stop <-chan struct{}, d time.Duration) {
+	c.forEachCheck(func(name string, rc *registeredCheck) {
+		interval := d
+		if rc.opts.Interval > 0 {
+			interval = rc.opts.Interval
 		}
-	}
+
+		go c.scheduleCheck(stop, name, withTimeout(rc.fn, rc.opts.Timeout), interval, c.promRegistry)
+	})
+
+	c.scheduleNeighbourhood(stop, d)
+
+	<-stop
 }
 
 // StopScheduled is used to stop the scheduled run of checks.
@@ -170,6 +226,18 @@ func (c *Checker) APIServerDNS(ctx context.Context) string {
 	return c.doRequest(ctx, apiurl, false)
 }
 
+// APIServerLoadBalancer checks the /readyz endpoint of the Kubernetes API Server through its
+// external LoadBalancer/HTTPS address, i.e. the same address external clients use. This is
+// distinct from APIServerDirect/APIServerDNS, which only prove reachability from inside the pod
+// network and can't catch LB or external-DNS failures.
+func (c *Checker) APIServerLoadBalancer(ctx context.Context) string {
+	if c.SkipCheckAPIServerLoadBalancer || c.APIServerLBURL == "" {
+		return skippedStr
+	}
+
+	return c.doRequest(ctx, c.APIServerLBURL+"/readyz", false)
+}
+
 // MeIngress checks if the kubenurse is reachable at the /alwayshappy endpoint behind the ingress
 func (c *Checker) MeIngress(ctx context.Context) string {
 	if c.SkipCheckMeIngress {
@@ -188,14 +256,13 @@ func (c *Checker) MeService(ctx context.Context) string {
 	return c.doRequest(ctx, c.KubenurseServiceURL+"/alwayshappy", false)
 }
 
-// measure implements metric collections for the check
+// measure implements metric collections for the check. The check itself is run through
+// recoverCheck, so a panic in check (or anything it calls, like an HTTP tracer callback) can
+// never leave wg's counter unbalanced or bring down the calling goroutine.
 func (c *Checker) measure(wg *sync.WaitGroup, res *sync.Map, check Check, requestType string) {
-	// Add our label (check type) to the context so our http tracer can annotate
-	// metrics and errors based with the label
 	defer wg.Done()
 
-	ctx := context.WithValue(context.Background(), kubenurseTypeKey{}, requestType)
-	res.Store(requestType, check(ctx))
+	res.Store(requestType, c.recoverCheck(requestType, check))
 }
 
 func podIPtoURL(podIP string, useTLS bool) string {
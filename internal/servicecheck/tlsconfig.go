@@ -0,0 +1,79 @@
+package servicecheck
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+)
+
+// TLS profile names accepted via KUBENURSE_TLS_PROFILE or the --tls-profile flag.
+const (
+	// TLSProfileSecure restricts kubenurse to TLS 1.3 only.
+	TLSProfileSecure = "secure"
+	// TLSProfileDefault allows TLS 1.2+ with a modern cipher suite selection. This is the default.
+	TLSProfileDefault = "default"
+	// TLSProfileLegacy allows TLS 1.2+ with any cipher suite that is not known to be broken, for
+	// compatibility with older neighbours/ingresses that can't be upgraded yet.
+	TLSProfileLegacy = "legacy"
+)
+
+// modernCipherSuites is the cipher suite selection used by the "default" profile. It only lists
+// suites that support forward secrecy and AEAD.
+var modernCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+}
+
+// legacyCipherSuites additionally allows the non-AEAD suites still considered secure, for
+// compatibility with older peers.
+var legacyCipherSuites = append(append([]uint16{}, modernCipherSuites...),
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+)
+
+// ServerTLSConfig builds the tls.Config for the kubenurse HTTPS server (the one serving
+// /alwayshappy on :8443) for the given profile, read from KUBENURSE_TLS_PROFILE when empty. It
+// lets operators enforce the same ciphersuite/version floor on the server side as is applied to
+// the outbound http.Transport built by New.
+func ServerTLSConfig(profile string) (*tls.Config, error) {
+	if profile == "" {
+		profile = os.Getenv("KUBENURSE_TLS_PROFILE")
+	}
+
+	tlsConfig := &tls.Config{}
+	if err := applyTLSProfile(tlsConfig, profile); err != nil {
+		return nil, err
+	}
+
+	return tlsConfig, nil
+}
+
+// applyTLSProfile mutates tlsConfig in place to apply the MinVersion/CipherSuites/
+// PreferServerCipherSuites settings for the given profile. It is used for both the outbound
+// http.Transport and the kubenurse HTTPS server, so that operators can enforce the same
+// ciphersuite/version floor in both directions.
+func applyTLSProfile(tlsConfig *tls.Config, profile string) error {
+	switch profile {
+	case "", TLSProfileDefault:
+		tlsConfig.MinVersion = tls.VersionTLS12
+		tlsConfig.CipherSuites = modernCipherSuites
+		tlsConfig.PreferServerCipherSuites = true //nolint:staticcheck // explicit for clarity, ignored since Go 1.17
+	case TLSProfileSecure:
+		tlsConfig.MinVersion = tls.VersionTLS13
+		// CipherSuites is ignored by crypto/tls for TLS 1.3, the suite is chosen automatically.
+	case TLSProfileLegacy:
+		tlsConfig.MinVersion = tls.VersionTLS12
+		tlsConfig.CipherSuites = legacyCipherSuites
+		tlsConfig.PreferServerCipherSuites = true //nolint:staticcheck // explicit for clarity, ignored since Go 1.17
+	default:
+		return fmt.Errorf("unknown tls profile %q, expected one of %q, %q, %q", profile, TLSProfileSecure, TLSProfileDefault, TLSProfileLegacy)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package servicecheck
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+func TestApplyTLSProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     string
+		wantMinVer  uint16
+		wantCiphers []uint16
+		wantErr     bool
+	}{
+		{
+			name:        "empty profile defaults to the default profile",
+			profile:     "",
+			wantMinVer:  tls.VersionTLS12,
+			wantCiphers: modernCipherSuites,
+		},
+		{
+			name:        "default profile uses modern cipher suites",
+			profile:     TLSProfileDefault,
+			wantMinVer:  tls.VersionTLS12,
+			wantCiphers: modernCipherSuites,
+		},
+		{
+			name:       "secure profile is TLS 1.3 only",
+			profile:    TLSProfileSecure,
+			wantMinVer: tls.VersionTLS13,
+		},
+		{
+			name:        "legacy profile uses the wider cipher suite list",
+			profile:     TLSProfileLegacy,
+			wantMinVer:  tls.VersionTLS12,
+			wantCiphers: legacyCipherSuites,
+		},
+		{
+			name:    "unknown profile is an error",
+			profile: "bogus",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig := &tls.Config{}
+
+			err := applyTLSProfile(tlsConfig, tt.profile)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("applyTLSProfile() = nil, want an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("applyTLSProfile() = %v, want nil", err)
+			}
+
+			if tlsConfig.MinVersion != tt.wantMinVer {
+				t.Errorf("MinVersion = %v, want %v", tlsConfig.MinVersion, tt.wantMinVer)
+			}
+
+			if len(tlsConfig.CipherSuites) != len(tt.wantCiphers) {
+				t.Errorf("CipherSuites = %v, want %v", tlsConfig.CipherSuites, tt.wantCiphers)
+			}
+		})
+	}
+}